@@ -0,0 +1,14 @@
+package backoff
+
+import "time"
+
+// Clock abstracts time.Now so Retrier's MaxElapsedTime and Deadline checks,
+// in Run and in NewTicker, can be driven by a fake clock in tests. The
+// sleeps between attempts and ticks always use real timers.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
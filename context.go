@@ -0,0 +1,44 @@
+package backoff
+
+import (
+	"context"
+	"time"
+)
+
+// RetryContext is like Retry but aborts as soon as ctx is cancelled, instead
+// of waiting out the remainder of the current sleep. The operation receives
+// ctx so it can itself be cancelled mid-flight.
+//
+// If ctx is cancelled by the time RetryContext waits between attempts, it
+// returns ctx.Err() wrapped around the last error returned by the operation.
+// A loop that ends without that wait (BackOff signals Stop, the deadline is
+// reached, or the error is permanent or rejected by the RetryPolicy) returns
+// the operation's error alone.
+func RetryContext(ctx context.Context, f func(context.Context) error, b BackOff, opts ...Option) error {
+	return RetryNotifyContext(ctx, f, b, nil, opts...)
+}
+
+// RetryNotifyContext is like RetryNotify but aborts as soon as ctx is
+// cancelled. See RetryContext for details.
+func RetryNotifyContext(ctx context.Context, f func(context.Context) error, b BackOff, notify func(err error, wait time.Duration), opts ...Option) error {
+	o := buildOptions(opts)
+	r := &Retrier{BackOff: b, RetryPolicy: o.policy, Deadline: o.deadline, Notify: notify}
+	return r.Run(ctx, f)
+}
+
+// ctxError wraps a context cancellation error together with the last error
+// returned by the operation being retried. Both are reachable via
+// errors.Is / errors.As through Unwrap, and Error() reports both.
+type ctxError struct {
+	ctxErr  error
+	lastErr error
+}
+
+func (e *ctxError) Error() string {
+	if e.lastErr == nil {
+		return e.ctxErr.Error()
+	}
+	return e.ctxErr.Error() + ": " + e.lastErr.Error()
+}
+
+func (e *ctxError) Unwrap() []error { return []error{e.ctxErr, e.lastErr} }
@@ -0,0 +1,33 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryContext_CancelDuringSleep(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errSentinel := errors.New("sentinel")
+
+	b := &constBackOff{interval: time.Hour}
+	done := make(chan error, 1)
+	go func() {
+		done <- RetryContext(ctx, func(context.Context) error { return errSentinel }, b)
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled in chain, got %v", err)
+		}
+		if !errors.Is(err, errSentinel) {
+			t.Fatalf("expected sentinel error in chain, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("RetryContext did not return promptly after cancellation")
+	}
+}
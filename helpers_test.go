@@ -0,0 +1,38 @@
+package backoff
+
+import "time"
+
+// constBackOff returns interval every time, up to max calls (0 means
+// unlimited), after which it signals Stop.
+type constBackOff struct {
+	interval time.Duration
+	max      int
+	calls    int
+}
+
+func (b *constBackOff) NextBackOff() time.Duration {
+	if b.max > 0 && b.calls >= b.max {
+		return Stop
+	}
+	b.calls++
+	return b.interval
+}
+
+func (b *constBackOff) Reset() { b.calls = 0 }
+
+// seqBackOff returns each duration in intervals in order, then Stop.
+type seqBackOff struct {
+	intervals []time.Duration
+	calls     int
+}
+
+func (b *seqBackOff) NextBackOff() time.Duration {
+	if b.calls >= len(b.intervals) {
+		return Stop
+	}
+	next := b.intervals[b.calls]
+	b.calls++
+	return next
+}
+
+func (b *seqBackOff) Reset() { b.calls = 0 }
@@ -0,0 +1,60 @@
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Jitter selects a strategy for randomizing the interval a BackOff
+// computes, to avoid many clients retrying in lockstep.
+type Jitter int
+
+const (
+	// NoJitter uses the BackOff's computed interval unmodified.
+	NoJitter Jitter = iota
+
+	// FullJitter picks a random duration in [0, interval).
+	FullJitter
+
+	// EqualJitter picks a random duration in [interval/2, interval).
+	EqualJitter
+
+	// DecorrelatedJitter implements the AWS "decorrelated jitter"
+	// recurrence: next = min(cap, random_between(base, prev*3)), seeded
+	// from base. A Retrier by default takes base from the interval the
+	// wrapped BackOff computed for the first attempt; the intervals it
+	// computes for later attempts do not enter the recurrence.
+	DecorrelatedJitter
+)
+
+// applyJitter applies j to interval. base and cap bound the
+// DecorrelatedJitter recurrence; prev is the previously jittered interval,
+// also only used by DecorrelatedJitter, and should be 0 on the first
+// attempt.
+func applyJitter(j Jitter, interval, base, cap, prev time.Duration) time.Duration {
+	switch j {
+	case FullJitter:
+		return randDuration(0, interval)
+	case EqualJitter:
+		return interval/2 + randDuration(0, interval-interval/2)
+	case DecorrelatedJitter:
+		if prev <= 0 {
+			prev = base
+		}
+		next := randDuration(base, prev*3)
+		if next > cap {
+			next = cap
+		}
+		return next
+	default:
+		return interval
+	}
+}
+
+// randDuration returns a random duration in [min, max), or min if max<=min.
+func randDuration(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(rand.Int63n(int64(max-min)))
+}
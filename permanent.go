@@ -0,0 +1,81 @@
+package backoff
+
+import (
+	"errors"
+	"time"
+)
+
+// PermanentError signals that retrying is futile. Retry, RetryNotify and
+// RetryN stop as soon as they see one, returning the underlying error
+// without consulting NextBackOff or sleeping.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent wraps err so that Retry, RetryNotify and RetryN treat it as
+// non-transient and stop retrying immediately. Permanent(nil) returns nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// RetryPolicy classifies an error returned by an operation. It returns false
+// for errors that should stop the retry loop immediately.
+type RetryPolicy func(err error) bool
+
+// Option configures optional behavior of Retry, RetryNotify and RetryN.
+type Option func(*retryOptions)
+
+type retryOptions struct {
+	policy   RetryPolicy
+	deadline time.Time
+}
+
+// WithRetryPolicy sets a RetryPolicy used to classify errors returned by the
+// operation, in addition to the built-in PermanentError check.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *retryOptions) { o.policy = policy }
+}
+
+// WithDeadline sets a wall-clock deadline the retry loop must not sleep
+// past: once the next computed interval would end after deadline, the loop
+// stops and returns the last error instead of sleeping. See RetryUntil.
+func WithDeadline(deadline time.Time) Option {
+	return func(o *retryOptions) { o.deadline = deadline }
+}
+
+func buildOptions(opts []Option) *retryOptions {
+	o := &retryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// unwrapPermanent returns the error that Retry, RetryNotify and RetryN
+// should surface to the caller: the wrapped error if err is a
+// *PermanentError, or err itself otherwise.
+func unwrapPermanent(err error) error {
+	var perr *PermanentError
+	if errors.As(err, &perr) {
+		return perr.Err
+	}
+	return err
+}
+
+// stopRetrying reports whether err should stop the retry loop immediately,
+// either because it unwraps to a *PermanentError or because the configured
+// RetryPolicy classifies it as non-retryable.
+func stopRetrying(err error, o *retryOptions) bool {
+	var perr *PermanentError
+	if errors.As(err, &perr) {
+		return true
+	}
+	return o.policy != nil && !o.policy(err)
+}
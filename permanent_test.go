@@ -0,0 +1,63 @@
+package backoff
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryNotify_PermanentStopsImmediately(t *testing.T) {
+	errSentinel := errors.New("auth failed")
+	b := &constBackOff{interval: time.Millisecond}
+	calls := 0
+
+	err := RetryNotify(func() error {
+		calls++
+		return Permanent(errSentinel)
+	}, b, nil)
+
+	if !errors.Is(err, errSentinel) {
+		t.Fatalf("expected sentinel error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected exactly 1 call, got %d", calls)
+	}
+}
+
+func TestRetryNotify_RetryPolicyStopsImmediately(t *testing.T) {
+	errTransient := errors.New("not found")
+	b := &constBackOff{interval: time.Millisecond}
+	calls := 0
+
+	err := RetryNotify(func() error {
+		calls++
+		return errTransient
+	}, b, nil, WithRetryPolicy(func(error) bool { return false }))
+
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("expected transient error unchanged, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected exactly 1 call, got %d", calls)
+	}
+}
+
+func TestRetryNotify_RetryPolicyAllowsRetry(t *testing.T) {
+	b := &constBackOff{interval: time.Millisecond, max: 5}
+	calls := 0
+
+	err := RetryNotify(func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	}, b, nil, WithRetryPolicy(func(error) bool { return true }))
+
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
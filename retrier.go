@@ -0,0 +1,219 @@
+package backoff
+
+import (
+	"context"
+	"time"
+)
+
+// Retrier drives the retry loop shared by every Retry* function in this
+// package: jitter, a wall-clock ceiling, an attempt ceiling, error
+// classification, and a Ticker-style API for callers that want to select on
+// retries instead of blocking in a sleep. The zero value is not usable;
+// construct one with NewRetrier.
+type Retrier struct {
+	// BackOff supplies the unjittered interval for each attempt.
+	BackOff BackOff
+
+	// Clock is used for the MaxElapsedTime and Deadline checks, in Run and
+	// in NewTicker; the sleeps themselves use real timers. Defaults to the
+	// system clock when nil.
+	Clock Clock
+
+	// Jitter is the strategy applied to each interval BackOff computes.
+	// Defaults to NoJitter.
+	Jitter Jitter
+
+	// JitterBase and JitterCap bound the DecorrelatedJitter recurrence.
+	// When zero, JitterBase defaults to the first attempt's BackOff
+	// interval and JitterCap to defaultJitterCapMultiplier times that,
+	// both fixed for the rest of the retry sequence. Ignored by other
+	// strategies.
+	JitterBase time.Duration
+	JitterCap  time.Duration
+
+	// MaxElapsedTime bounds the total time spent retrying. Zero means no
+	// limit.
+	MaxElapsedTime time.Duration
+
+	// Deadline, if non-zero, is the wall-clock time the loop must not
+	// sleep past. See RetryUntil.
+	Deadline time.Time
+
+	// MaxAttempts bounds the number of calls made to the operation,
+	// however the loop is driven. Zero means no limit. NewTicker honors
+	// the same count by emitting MaxAttempts-1 ticks, matching the
+	// documented pattern of one initial call to f plus one call per tick
+	// received.
+	MaxAttempts int
+
+	// RetryPolicy classifies errors returned by the operation, in
+	// addition to the built-in PermanentError check. See RetryPolicy.
+	RetryPolicy RetryPolicy
+
+	// Notify, if set, is called with the error and wait duration before
+	// each sleep.
+	Notify func(err error, wait time.Duration)
+}
+
+// NewRetrier returns a Retrier wrapping b with no jitter and no ceilings.
+func NewRetrier(b BackOff) *Retrier {
+	return &Retrier{BackOff: b}
+}
+
+// defaultJitterCapMultiplier bounds DecorrelatedJitter when JitterCap is
+// left zero: the cap defaults to this many multiples of JitterBase.
+const defaultJitterCapMultiplier = 100
+
+// jitterState tracks the DecorrelatedJitter recurrence across a single
+// Run or NewTicker sequence: base and cap are resolved once, from the
+// first attempt's interval, and held fixed thereafter.
+type jitterState struct {
+	base time.Duration
+	cap  time.Duration
+	prev time.Duration
+}
+
+// Run retries f(ctx) until it succeeds, ctx is cancelled, BackOff signals
+// Stop, or one of r's ceilings is reached. It is the single loop that
+// Retry, RetryNotify, RetryN, RetryContext and RetryNotifyContext all run
+// through.
+func (r *Retrier) Run(ctx context.Context, f func(context.Context) error) error {
+	clock := r.clock()
+	opts := &retryOptions{policy: r.RetryPolicy}
+
+	r.BackOff.Reset()
+	start := clock.Now()
+	js := &jitterState{}
+	attempts := 0
+	var err error
+
+	for {
+		if err = f(ctx); err == nil {
+			return nil
+		}
+
+		if stopRetrying(err, opts) {
+			return unwrapPermanent(err)
+		}
+
+		attempts++
+		if r.MaxAttempts > 0 && attempts >= r.MaxAttempts {
+			return err
+		}
+
+		next, ok := r.nextInterval(clock, start, js)
+		if !ok {
+			return err
+		}
+
+		if r.Notify != nil {
+			r.Notify(err, next)
+		}
+
+		t := time.NewTimer(next)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return &ctxError{ctxErr: ctx.Err(), lastErr: err}
+		case <-t.C:
+		}
+	}
+}
+
+// NewTicker starts a goroutine that feeds r's jittered, ceiling-aware
+// intervals into the returned channel, so callers can drive retries from a
+// select alongside other channels. The usage pattern is one initial call to
+// the operation followed by one more call per tick received; MaxAttempts
+// bounds that same total. The channel is closed when BackOff signals Stop,
+// a ceiling is reached, or ctx is cancelled.
+func (r *Retrier) NewTicker(ctx context.Context) <-chan time.Time {
+	c := make(chan time.Time)
+	go r.runTicker(ctx, c)
+	return c
+}
+
+func (r *Retrier) runTicker(ctx context.Context, c chan<- time.Time) {
+	defer close(c)
+
+	clock := r.clock()
+	r.BackOff.Reset()
+	start := clock.Now()
+	js := &jitterState{}
+	ticks := 0
+
+	for {
+		if r.MaxAttempts > 0 && ticks >= r.MaxAttempts-1 {
+			return
+		}
+
+		next, ok := r.nextInterval(clock, start, js)
+		if !ok {
+			return
+		}
+
+		t := time.NewTimer(next)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case tm := <-t.C:
+			select {
+			case c <- tm:
+				ticks++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// nextInterval returns the next jittered interval to sleep, and ok=false if
+// the loop should stop because BackOff is exhausted or sleeping for that
+// interval would cross MaxElapsedTime or Deadline.
+func (r *Retrier) nextInterval(clock Clock, start time.Time, js *jitterState) (next time.Duration, ok bool) {
+	next = r.BackOff.NextBackOff()
+	if next == Stop {
+		return 0, false
+	}
+	next = r.jitter(next, js)
+
+	if r.MaxElapsedTime > 0 && clock.Now().Add(next).Sub(start) > r.MaxElapsedTime {
+		return 0, false
+	}
+	if !r.Deadline.IsZero() && clock.Now().Add(next).After(r.Deadline) {
+		return 0, false
+	}
+	return next, true
+}
+
+// jitter applies r.Jitter to interval. For DecorrelatedJitter it resolves
+// js.base/js.cap once, from the first interval seen, and holds them fixed
+// for the rest of the sequence so the recurrence actually varies instead of
+// collapsing back to interval every attempt.
+func (r *Retrier) jitter(interval time.Duration, js *jitterState) time.Duration {
+	if r.Jitter == DecorrelatedJitter {
+		if js.base <= 0 {
+			js.base = r.JitterBase
+			if js.base <= 0 {
+				js.base = interval
+			}
+		}
+		if js.cap <= 0 {
+			js.cap = r.JitterCap
+			if js.cap <= 0 {
+				js.cap = js.base * defaultJitterCapMultiplier
+			}
+		}
+	}
+
+	next := applyJitter(r.Jitter, interval, js.base, js.cap, js.prev)
+	js.prev = next
+	return next
+}
+
+func (r *Retrier) clock() Clock {
+	if r.Clock != nil {
+		return r.Clock
+	}
+	return systemClock{}
+}
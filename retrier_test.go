@@ -0,0 +1,121 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestApplyJitter_FullAndEqualBounds(t *testing.T) {
+	interval := 100 * time.Millisecond
+	for i := 0; i < 100; i++ {
+		full := applyJitter(FullJitter, interval, interval, interval, 0)
+		if full < 0 || full >= interval {
+			t.Fatalf("FullJitter out of bounds: %v", full)
+		}
+		equal := applyJitter(EqualJitter, interval, interval, interval, 0)
+		if equal < interval/2 || equal >= interval {
+			t.Fatalf("EqualJitter out of bounds: %v", equal)
+		}
+	}
+}
+
+func TestApplyJitter_DecorrelatedRespectsBaseAndCap(t *testing.T) {
+	base := 10 * time.Millisecond
+	cap := 100 * time.Millisecond
+	prev := time.Duration(0)
+	for i := 0; i < 100; i++ {
+		next := applyJitter(DecorrelatedJitter, base, base, cap, prev)
+		if next < base || next > cap {
+			t.Fatalf("DecorrelatedJitter out of [base, cap]: %v", next)
+		}
+		prev = next
+	}
+}
+
+// TestRetrier_MaxAttemptsMatchesRunAndTicker verifies that MaxAttempts
+// bounds the same number of operation calls whether the caller drives
+// retries through Run or through the documented NewTicker usage pattern
+// (one initial call to f, then one more per tick received).
+func TestRetrier_MaxAttemptsMatchesRunAndTicker(t *testing.T) {
+	const maxAttempts = 3
+
+	runCalls := 0
+	r := &Retrier{BackOff: &constBackOff{interval: time.Millisecond}, MaxAttempts: maxAttempts}
+	_ = r.Run(context.Background(), func(context.Context) error {
+		runCalls++
+		return errors.New("fail")
+	})
+	if runCalls != maxAttempts {
+		t.Fatalf("Run: expected %d calls, got %d", maxAttempts, runCalls)
+	}
+
+	ticker := &Retrier{BackOff: &constBackOff{interval: time.Millisecond}, MaxAttempts: maxAttempts}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	tickerCalls := 1 // the initial call, per documented usage
+	for range ticker.NewTicker(ctx) {
+		tickerCalls++
+	}
+	if tickerCalls != maxAttempts {
+		t.Fatalf("NewTicker: expected %d total calls, got %d", maxAttempts, tickerCalls)
+	}
+}
+
+// TestRetrier_DecorrelatedJitterVariesWithDefaults guards against
+// DecorrelatedJitter silently degrading to NoJitter when JitterBase/
+// JitterCap are left at their zero defaults: base/cap must be resolved once
+// from the first interval and held fixed, not recomputed from each
+// attempt's (growing) interval, or the recurrence collapses back to it.
+func TestRetrier_DecorrelatedJitterVariesWithDefaults(t *testing.T) {
+	b := &seqBackOff{intervals: []time.Duration{
+		100 * time.Microsecond,
+		200 * time.Microsecond,
+		300 * time.Microsecond,
+		400 * time.Microsecond,
+		500 * time.Microsecond,
+	}}
+	var waits []time.Duration
+	r := &Retrier{
+		BackOff: b,
+		Jitter:  DecorrelatedJitter,
+		Notify:  func(err error, wait time.Duration) { waits = append(waits, wait) },
+	}
+
+	_ = r.Run(context.Background(), func(context.Context) error {
+		return errors.New("fail")
+	})
+
+	if len(waits) == 0 {
+		t.Fatal("expected at least one notified wait")
+	}
+
+	varied := false
+	for i, w := range waits {
+		if w != b.intervals[i] {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Fatalf("DecorrelatedJitter never varied from the raw BackOff intervals: %v", waits)
+	}
+}
+
+func TestRetrier_NewTicker_StopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Retrier{BackOff: &constBackOff{interval: time.Hour}}
+	ticks := r.NewTicker(ctx)
+
+	cancel()
+
+	select {
+	case _, ok := <-ticks:
+		if ok {
+			t.Fatal("expected channel to be closed, got a tick")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("NewTicker did not close its channel after cancellation")
+	}
+}
@@ -1,6 +1,9 @@
 package backoff
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Retry the function f until it does not return error or BackOff stops.
 // f is guaranteed to be run at least once.
@@ -21,7 +24,7 @@ import "time"
 //
 // 	// Operation is successfull.
 //
-func Retry(f func() error, b BackOff) error { return RetryNotify(f, b, nil) }
+func Retry(f func() error, b BackOff, opts ...Option) error { return RetryNotify(f, b, nil, opts...) }
 
 type ErrorHandler func(wait time.Duration, err error)
 
@@ -32,49 +35,28 @@ type RetryStrategy struct {
 }
 
 // RetryN Like Retry but will retry only up to N times
-func RetryN(n int, b BackOff, notify ErrorHandler, f func() error) error {
-	var err error
-	var next time.Duration
-
-	b.Reset()
-	for i := 0; i < n; i++ {
-		if err = f(); err == nil {
-			return nil
-		}
-
-		if next = b.NextBackOff(); next == Stop {
-			return err
-		}
-
-		if notify != nil {
-			notify(next, err)
-		}
+func RetryN(n int, b BackOff, notify ErrorHandler, f func() error, opts ...Option) error {
+	if n <= 0 {
+		return nil
+	}
 
-		time.Sleep(next)
+	o := buildOptions(opts)
+	r := &Retrier{BackOff: b, MaxAttempts: n, RetryPolicy: o.policy, Deadline: o.deadline}
+	if notify != nil {
+		r.Notify = func(err error, wait time.Duration) { notify(wait, err) }
 	}
+	return r.Run(context.Background(), func(context.Context) error { return f() })
+}
 
-	return err
+// RetryUntil is like RetryN but also stops before sleeping past deadline,
+// rather than being bounded only by the attempt count.
+func RetryUntil(deadline time.Time, n int, b BackOff, notify ErrorHandler, f func() error, opts ...Option) error {
+	return RetryN(n, b, notify, f, append(opts, WithDeadline(deadline))...)
 }
 
 // RetryNotify calls notify function with the error and wait duration for each failed attempt before sleep.
-func RetryNotify(f func() error, b BackOff, notify func(err error, wait time.Duration)) error {
-	var err error
-	var next time.Duration
-
-	b.Reset()
-	for {
-		if err = f(); err == nil {
-			return nil
-		}
-
-		if next = b.NextBackOff(); next == Stop {
-			return err
-		}
-
-		if notify != nil {
-			notify(err, next)
-		}
-
-		time.Sleep(next)
-	}
+func RetryNotify(f func() error, b BackOff, notify func(err error, wait time.Duration), opts ...Option) error {
+	o := buildOptions(opts)
+	r := &Retrier{BackOff: b, RetryPolicy: o.policy, Deadline: o.deadline, Notify: notify}
+	return r.Run(context.Background(), func(context.Context) error { return f() })
 }
@@ -0,0 +1,24 @@
+package backoff
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryUntil_StopsBeforeSleepingPastDeadline(t *testing.T) {
+	errTransient := errors.New("transient")
+	b := &constBackOff{interval: 50 * time.Millisecond, max: 100}
+	deadline := time.Now().Add(10 * time.Millisecond)
+
+	start := time.Now()
+	err := RetryUntil(deadline, 100, b, nil, func() error { return errTransient })
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("expected transient error, got %v", err)
+	}
+	if elapsed > 40*time.Millisecond {
+		t.Fatalf("RetryUntil slept past its deadline: elapsed %v", elapsed)
+	}
+}